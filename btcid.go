@@ -2,18 +2,16 @@ package btcid
 
 import (
 	"bytes"
-	"crypto/hmac"
-	"crypto/sha512"
-	"encoding/hex"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
-	"time"
+	"sync"
+
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -21,8 +19,9 @@ const (
 	pubAPIEndpoint       = "/api"
 	privAPIEndpoint      = "/tapi"
 	endpointTicker       = "/ticker"
-	pairBTCIDR           = "/btc_idr"
-	pairETHIDR           = "/eth_idr"
+	endpointTrades       = "/trades"
+	endpointDepth        = "/depth"
+	endpointPairs        = "/pairs"
 	prvMethodGetInfo     = "getInfo"
 	prvMethodTransHist   = "transHistory"
 	prvMethodTrade       = "trade"
@@ -34,17 +33,25 @@ const (
 	prvMethodWithdCoin   = "withdrawCoin"
 )
 
-var (
-	endpointBTCIDRTicker = fmt.Sprintf("%s%s", pairBTCIDR, endpointTicker)
-	endpointETHIDRTicker = fmt.Sprintf("%s%s", pairETHIDR, endpointTicker)
-)
-
 // Client holds the credentials and config for the BTCID client
 type Client struct {
-	APIKey     string
-	Secret     string
-	Domain     string
-	HTTPClient *http.Client
+	APIKey      string
+	Secret      string
+	Domain      string
+	HTTPClient  *http.Client
+	NonceSource NonceSource
+	Signer      Signer
+
+	// prvMu serializes nonce generation and signing for private requests so
+	// concurrent callers never emit out-of-order or duplicate nonces. It is
+	// held only while building and signing a request, not across the HTTP
+	// round trip, so concurrent private calls can still be in flight at once.
+	prvMu sync.Mutex
+
+	// publicLimiter and privateLimiter gate newPubReq/newPrvReq so the
+	// client backs off before exceeding BTCID's per-endpoint quotas.
+	publicLimiter  *rate.Limiter
+	privateLimiter *rate.Limiter
 }
 
 type Ticker struct {
@@ -79,152 +86,200 @@ type UserInfo struct {
 	Email          string                 `json:"email"`
 }
 
-type InfoRes struct {
-	Success int      `json:"success"`
-	Return  UserInfo `json:"return"`
-}
-
 // New assembles a new BTCID client struct.
 // an http client can be passed to be shared if there is an underlying client existing.
 // if nil is passed as a client, a default client will be set up
-func New(APIKey, Secret string, HTTPClient *http.Client) Client {
+// Options such as WithPublicRate/WithPrivateRate may be passed to override
+// the default request quotas.
+//
+// New returns a *Client, not a Client, because Client embeds a mutex
+// guarding nonce/signature generation for private requests: copying a
+// Client (e.g. by returning or passing it by value) would copy that mutex
+// mid-use and is caught by `go vet`.
+func New(APIKey, Secret string, HTTPClient *http.Client, opts ...Option) *Client {
 	if HTTPClient == nil {
 		HTTPClient = http.DefaultClient
 	}
-	return Client{
-		APIKey:     APIKey,
-		Secret:     Secret,
-		HTTPClient: HTTPClient,
-		Domain:     baseURL,
+	c := &Client{
+		APIKey:         APIKey,
+		Secret:         Secret,
+		HTTPClient:     HTTPClient,
+		Domain:         baseURL,
+		NonceSource:    NewAtomicNonceSource(),
+		Signer:         NewHMACSHA512Signer(APIKey, Secret),
+		publicLimiter:  rate.NewLimiter(defaultPublicRate, defaultPublicBurst),
+		privateLimiter: rate.NewLimiter(defaultPrivateRate, defaultPrivateBurst),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
-func (c *Client) newPrvReq(PrivateMethod string) ([]byte, error) {
+func (c *Client) newPrvReq(ctx context.Context, PrivateMethod string, params url.Values) ([]byte, error) {
+	if err := c.privateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRateLimited, err)
+	}
+
+	// prvMu only guards nonce generation and signing, so concurrent private
+	// calls serialize their signature+nonce emission but still run their
+	// HTTP round trips in parallel.
+	c.prvMu.Lock()
+
 	// Prepare variables for signing and sending
-	nonce := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce, err := c.NonceSource.Next()
+	if err != nil {
+		c.prvMu.Unlock()
+		return nil, fmt.Errorf("btcid: generating nonce: %w", err)
+	}
 
 	// Build URL query parameters
 	q := url.Values{}
 	q.Set("method", PrivateMethod)
 	q.Set("nonce", nonce)
+	for key, values := range params {
+		for _, value := range values {
+			q.Add(key, value)
+		}
+	}
 	queryString := q.Encode()
 
-	// Setup Request
-	url := fmt.Sprintf("%s%s", c.Domain, privAPIEndpoint)
-	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(queryString))
+	// Sign request
+	_, headers, err := c.Signer.Sign([]byte(queryString))
 	if err != nil {
-		log.Print(err)
-		return nil, err
+		c.prvMu.Unlock()
+		return nil, fmt.Errorf("btcid: signing %s request: %w", PrivateMethod, err)
 	}
+	c.prvMu.Unlock()
 
-	// Sign request
-	hmac512 := hmac.New(sha512.New, []byte(c.Secret))
-	hmac512.Write([]byte(queryString))
-	signature := hex.EncodeToString(hmac512.Sum(nil))
+	// Setup Request
+	endpoint := fmt.Sprintf("%s%s", c.Domain, privAPIEndpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(queryString))
+	if err != nil {
+		return nil, fmt.Errorf("btcid: building %s request: %w", PrivateMethod, err)
+	}
 
 	// Set headers
-	req.Header.Set("Key", c.APIKey)
-	req.Header.Set("Sign", signature)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	// Execute request
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		fmt.Println("Res error", err)
-		return nil, err
+		return nil, fmt.Errorf("btcid: %s: %w", PrivateMethod, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusTooManyRequests {
+		applyRetryAfter(c.privateLimiter, res)
+		return nil, fmt.Errorf("%w: %s: server returned 429", ErrRateLimited, PrivateMethod)
 	}
 
 	// Read the response
 	body, err := ioutil.ReadAll(res.Body)
-	defer res.Body.Close()
 	if err != nil {
-		fmt.Println("Read error", err)
-		return nil, err
+		return nil, fmt.Errorf("btcid: %s: reading response: %w", PrivateMethod, err)
 	}
 
 	return body, nil
 }
 
-func (c *Client) newPubReq(endpoint string) ([]byte, error) {
-	url := fmt.Sprintf("%s%s%s", c.Domain, pubAPIEndpoint, endpoint)
-	payload := bytes.NewBuffer([]byte{})
-	req, err := http.NewRequest(http.MethodGet, url, payload)
+func (c *Client) newPubReq(ctx context.Context, endpoint string) ([]byte, error) {
+	if err := c.publicLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRateLimited, err)
+	}
+
+	reqURL := fmt.Sprintf("%s%s%s", c.Domain, pubAPIEndpoint, endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, bytes.NewBuffer([]byte{}))
 	if err != nil {
-		fmt.Println("Req error", err)
-		return nil, err
+		return nil, fmt.Errorf("btcid: building request for %s: %w", endpoint, err)
 	}
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		fmt.Println("Res error", err)
-		return nil, err
+		return nil, fmt.Errorf("btcid: %s: %w", endpoint, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusTooManyRequests {
+		applyRetryAfter(c.publicLimiter, res)
+		return nil, fmt.Errorf("%w: %s: server returned 429", ErrRateLimited, endpoint)
 	}
 
 	body, err := ioutil.ReadAll(res.Body)
-	defer res.Body.Close()
 	if err != nil {
-		fmt.Println("Read error", err)
-		return nil, err
+		return nil, fmt.Errorf("btcid: %s: reading response: %w", endpoint, err)
 	}
 
 	return body, nil
 }
 
-// GetTicker fetches the latest ticker data from the API
-func (c *Client) GetTicker() (Ticker, error) {
-	body, err := c.newPubReq("/btc_idr/ticker")
+// GetTicker fetches the latest ticker data for a pair from the API
+func (c *Client) GetTicker(pair Pair) (Ticker, error) {
+	return c.GetTickerContext(context.Background(), pair)
+}
+
+// GetTickerContext is GetTicker with a caller-supplied context.
+func (c *Client) GetTickerContext(ctx context.Context, pair Pair) (Ticker, error) {
+	body, err := c.newPubReq(ctx, pair.endpoint()+endpointTicker)
 	if err != nil {
-		fmt.Println("Req error")
+		return Ticker{}, err
 	}
 	ticker := struct {
 		Ticker Ticker `json:"ticker"`
 	}{}
-	err = json.Unmarshal(body, &ticker)
-	if err != nil {
-		fmt.Println("JSON error", err)
-		return Ticker{}, err
+	if err := json.Unmarshal(body, &ticker); err != nil {
+		return Ticker{}, fmt.Errorf("btcid: getTicker: decoding response: %w", err)
 	}
 	return ticker.Ticker, nil
 }
 
-// GetTrades fetches the latest market trade data from the API
-func (c *Client) GetTrades() ([]Trade, error) {
-	body, err := c.newPubReq("/btc_idr/trades")
+// GetTrades fetches the latest market trade data for a pair from the API
+func (c *Client) GetTrades(pair Pair) ([]Trade, error) {
+	return c.GetTradesContext(context.Background(), pair)
+}
+
+// GetTradesContext is GetTrades with a caller-supplied context.
+func (c *Client) GetTradesContext(ctx context.Context, pair Pair) ([]Trade, error) {
+	body, err := c.newPubReq(ctx, pair.endpoint()+endpointTrades)
 	if err != nil {
-		fmt.Println("Req error", err)
+		return nil, err
 	}
 	trades := []Trade{}
-	err = json.Unmarshal(body, &trades)
-	if err != nil {
-		fmt.Println("Trade error", err)
+	if err := json.Unmarshal(body, &trades); err != nil {
+		return nil, fmt.Errorf("btcid: getTrades: decoding response: %w", err)
 	}
 	return trades, nil
 }
 
-// GetDepth fetches the market cap data from the API
-func (c *Client) GetDepth() (Depth, error) {
-	body, err := c.newPubReq("/btc_idr/depth")
+// GetDepth fetches the order book depth for a pair from the API
+func (c *Client) GetDepth(pair Pair) (Depth, error) {
+	return c.GetDepthContext(context.Background(), pair)
+}
+
+// GetDepthContext is GetDepth with a caller-supplied context.
+func (c *Client) GetDepthContext(ctx context.Context, pair Pair) (Depth, error) {
+	body, err := c.newPubReq(ctx, pair.endpoint()+endpointDepth)
 	if err != nil {
-		fmt.Println("Req error", err)
+		return Depth{}, err
 	}
 	depth := Depth{}
-	err = json.Unmarshal(body, &depth)
-	if err != nil {
-		fmt.Println("Depth error", err)
+	if err := json.Unmarshal(body, &depth); err != nil {
+		return Depth{}, fmt.Errorf("btcid: getDepth: decoding response: %w", err)
 	}
 	return depth, nil
 }
 
 // GetInfo fetches an account's information details
 func (c *Client) GetInfo() (UserInfo, error) {
-	body, err := c.newPrvReq(prvMethodGetInfo)
-	if err != nil {
-		fmt.Println("Req error", err)
-	}
-	infoRes := InfoRes{}
-	err = json.Unmarshal(body, &infoRes)
+	return c.GetInfoContext(context.Background())
+}
+
+// GetInfoContext is GetInfo with a caller-supplied context.
+func (c *Client) GetInfoContext(ctx context.Context) (UserInfo, error) {
+	body, err := c.newPrvReq(ctx, prvMethodGetInfo, nil)
 	if err != nil {
-		fmt.Println("Info error", err)
+		return UserInfo{}, err
 	}
-	return infoRes.Return, nil
+	return decodeEnvelope[UserInfo](prvMethodGetInfo, body)
 }
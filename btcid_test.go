@@ -80,7 +80,7 @@ func TestGetTicker(t *testing.T) {
 	}
 	for name := range tests {
 		t.Run(name, func(t *testing.T) {
-			_, err := client.GetTicker()
+			_, err := client.GetTicker(PairBTCIDR)
 			assert.Nil(t, err)
 		})
 	}
@@ -0,0 +1,46 @@
+package btcid
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError represents a failure reported by BTCID itself (a
+// {"success":0,...} envelope), as opposed to a transport-level failure
+// reaching the API.
+type APIError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("btcid: %s: %s (code %d)", e.Method, e.Message, e.Code)
+	}
+	return fmt.Sprintf("btcid: %s: %s", e.Method, e.Message)
+}
+
+// envelope is the {success, error, return} shape every private BTCID
+// endpoint wraps its payload in.
+type envelope[T any] struct {
+	Success   int    `json:"success"`
+	Error     string `json:"error"`
+	ErrorCode int    `json:"error_code"`
+	Return    T      `json:"return"`
+}
+
+// decodeEnvelope unmarshals body as an envelope around T, surfacing a
+// success:0 response as an *APIError instead of silently returning a zero
+// value.
+func decodeEnvelope[T any](method string, body []byte) (T, error) {
+	var env envelope[T]
+	var zero T
+	if err := json.Unmarshal(body, &env); err != nil {
+		return zero, fmt.Errorf("btcid: %s: decoding response: %w", method, err)
+	}
+	if env.Success == 0 {
+		return zero, &APIError{Method: method, Code: env.ErrorCode, Message: env.Error}
+	}
+	return env.Return, nil
+}
@@ -0,0 +1,121 @@
+package btcid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NonceSource produces the strictly increasing nonce values required by
+// BTCID's private API. Implementations must be safe for concurrent use.
+type NonceSource interface {
+	Next() (string, error)
+}
+
+// AtomicNonceSource is an in-memory NonceSource seeded from the current
+// time in nanoseconds and incremented atomically on every call. It survives
+// sub-second concurrent callers but not process restarts.
+type AtomicNonceSource struct {
+	counter int64
+}
+
+// NewAtomicNonceSource returns an AtomicNonceSource seeded from
+// time.Now().UnixNano().
+func NewAtomicNonceSource() *AtomicNonceSource {
+	return &AtomicNonceSource{counter: time.Now().UnixNano()}
+}
+
+// Next returns the next nonce in the sequence.
+func (s *AtomicNonceSource) Next() (string, error) {
+	return strconv.FormatInt(atomic.AddInt64(&s.counter, 1), 10), nil
+}
+
+// FileNonceSource is a NonceSource that persists the last issued nonce to
+// disk, so the sequence survives process restarts and recovers cleanly if
+// the server clock moves backward.
+type FileNonceSource struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNonceSource returns a FileNonceSource backed by
+// ~/.btcid/nonce-<apiKey>. The file is created with O_EXCL if it does not
+// already exist, seeded from the current time in nanoseconds.
+func NewFileNonceSource(apiKey string) (*FileNonceSource, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("btcid: locating home directory: %w", err)
+	}
+	dir := filepath.Join(home, ".btcid")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("btcid: creating nonce directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("nonce-%s", apiKey))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	switch {
+	case err == nil:
+		defer f.Close()
+		if _, err := f.WriteString(strconv.FormatInt(time.Now().UnixNano(), 10)); err != nil {
+			return nil, fmt.Errorf("btcid: seeding nonce file: %w", err)
+		}
+	case os.IsExist(err):
+		// Another process already initialized the store; reuse it.
+	default:
+		return nil, fmt.Errorf("btcid: creating nonce file: %w", err)
+	}
+
+	return &FileNonceSource{path: path}, nil
+}
+
+// Next reads the last persisted nonce, advances it, and writes the new
+// value back before returning it. The read-modify-write is guarded by both
+// an in-process mutex and an OS-level advisory lock on the nonce file, so
+// concurrent callers in this process *and* concurrent processes sharing the
+// same file never observe or emit the same nonce. On Windows the advisory
+// lock is a no-op (see nonce_windows.go), so only callers within the same
+// process are serialized there.
+func (s *FileNonceSource) Next() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_RDWR, 0600)
+	if err != nil {
+		return "", fmt.Errorf("btcid: opening nonce file: %w", err)
+	}
+	defer f.Close()
+
+	if err := flockExclusive(f); err != nil {
+		return "", fmt.Errorf("btcid: locking nonce file: %w", err)
+	}
+	defer flockUnlock(f)
+
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("btcid: reading nonce file: %w", err)
+	}
+	last, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("btcid: corrupt nonce file %s: %w", s.path, err)
+	}
+
+	next := last + 1
+	if now := time.Now().UnixNano(); now > next {
+		next = now
+	}
+
+	if err := f.Truncate(0); err != nil {
+		return "", fmt.Errorf("btcid: truncating nonce file: %w", err)
+	}
+	if _, err := f.WriteAt([]byte(strconv.FormatInt(next, 10)), 0); err != nil {
+		return "", fmt.Errorf("btcid: writing nonce file: %w", err)
+	}
+
+	return strconv.FormatInt(next, 10), nil
+}
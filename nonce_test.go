@@ -0,0 +1,62 @@
+package btcid
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectNonces runs n goroutines each calling next() once and returns every
+// nonce produced, failing the test on any error.
+func collectNonces(t *testing.T, n int, next func() (string, error)) []int64 {
+	t.Helper()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	values := make([]int64, 0, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := next()
+			assert.NoError(t, err)
+			v, err := strconv.ParseInt(s, 10, 64)
+			assert.NoError(t, err)
+			mu.Lock()
+			values = append(values, v)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func assertStrictlyIncreasing(t *testing.T, values []int64) {
+	t.Helper()
+	for i := 1; i < len(values); i++ {
+		assert.Greater(t, values[i], values[i-1], "nonces must be unique and strictly increasing")
+	}
+}
+
+func TestAtomicNonceSourceConcurrent(t *testing.T) {
+	src := NewAtomicNonceSource()
+	values := collectNonces(t, 100, src.Next)
+	assertStrictlyIncreasing(t, values)
+}
+
+func TestFileNonceSourceConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nonce-test")
+	if err := os.WriteFile(path, []byte("0"), 0600); err != nil {
+		t.Fatalf("seeding nonce file: %v", err)
+	}
+	src := &FileNonceSource{path: path}
+
+	values := collectNonces(t, 100, src.Next)
+	assertStrictlyIncreasing(t, values)
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package btcid
+
+import (
+	"os"
+	"syscall"
+)
+
+// flockExclusive takes an OS-level exclusive advisory lock on f, blocking
+// until it is available. This is what makes FileNonceSource safe across
+// separate processes, not just separate goroutines.
+func flockExclusive(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// flockUnlock releases a lock taken by flockExclusive.
+func flockUnlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package btcid
+
+import "os"
+
+// flockExclusive is a no-op on Windows: FileNonceSource falls back to
+// same-process-only locking there (via its mutex), since we avoid taking on
+// a golang.org/x/sys/windows dependency for a single syscall.
+func flockExclusive(f *os.File) error {
+	return nil
+}
+
+// flockUnlock mirrors flockExclusive.
+func flockUnlock(f *os.File) error {
+	return nil
+}
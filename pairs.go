@@ -0,0 +1,65 @@
+package btcid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Pair identifies a tradable market, e.g. PairBTCIDR for "btc_idr".
+type Pair string
+
+// Pairs known to be listed at the time of writing. GetPairs fetches the
+// full, up-to-date catalog, so these are convenience constants rather than
+// an exhaustive list.
+const (
+	PairBTCIDR Pair = "btc_idr"
+	PairETHIDR Pair = "eth_idr"
+)
+
+// endpoint returns the public REST path segment for this pair, e.g.
+// "/btc_idr".
+func (p Pair) endpoint() string {
+	return "/" + string(p)
+}
+
+// baseCurrency returns the base currency of the pair, e.g. "btc_idr" -> "btc".
+func (p Pair) baseCurrency() string {
+	base, _, _ := strings.Cut(string(p), "_")
+	return base
+}
+
+// Market describes a single tradable pair as returned by GetPairs.
+type Market struct {
+	Pair          Pair   `json:"ticker_id"`
+	Symbol        string `json:"symbol"`
+	BaseCurrency  string `json:"traded_currency"`
+	QuoteCurrency string `json:"base_currency"`
+	TickSize      int    `json:"pricescale"`
+
+	// MinBaseAmount and MinTradedAmount are mapped the same way BaseCurrency
+	// and QuoteCurrency are above: BTCID's "base"/"traded" field names are
+	// swapped from their usual meaning, so the minimum on the base-currency
+	// leg comes from the traded_currency field and vice versa.
+	MinBaseAmount   string `json:"trade_min_traded_currency"`
+	MinTradedAmount string `json:"trade_min_base_currency"`
+}
+
+// GetPairs fetches the list of markets BTCID currently lists for trading.
+func (c *Client) GetPairs() ([]Market, error) {
+	return c.GetPairsContext(context.Background())
+}
+
+// GetPairsContext is GetPairs with a caller-supplied context.
+func (c *Client) GetPairsContext(ctx context.Context) ([]Market, error) {
+	body, err := c.newPubReq(ctx, endpointPairs)
+	if err != nil {
+		return nil, err
+	}
+	markets := []Market{}
+	if err := json.Unmarshal(body, &markets); err != nil {
+		return nil, fmt.Errorf("btcid: getPairs: decoding response: %w", err)
+	}
+	return markets, nil
+}
@@ -0,0 +1,358 @@
+package btcid
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OrderType identifies which side of the book an order is placed on.
+type OrderType string
+
+const (
+	OrderTypeBuy  OrderType = "buy"
+	OrderTypeSell OrderType = "sell"
+)
+
+// TradeParams are the parameters accepted by Client.Trade.
+type TradeParams struct {
+	Pair   Pair
+	Type   OrderType
+	Price  string
+	Amount string
+}
+
+func (p TradeParams) values() url.Values {
+	q := url.Values{}
+	q.Set("pair", string(p.Pair))
+	q.Set("type", string(p.Type))
+	q.Set("price", p.Price)
+	q.Set(p.Pair.baseCurrency(), p.Amount)
+	return q
+}
+
+// TradeResult is returned by a successful Client.Trade call.
+type TradeResult struct {
+	ReceiveAmount string `json:"receive_amount"`
+	SpendAmount   string `json:"spend_amount"`
+	OrderID       int    `json:"order_id"`
+	TradeID       string `json:"trade_id"`
+	Remaining     string `json:"remain_amount"`
+}
+
+// Trade places a buy or sell order for the given pair.
+func (c *Client) Trade(params TradeParams) (TradeResult, error) {
+	return c.TradeContext(context.Background(), params)
+}
+
+// TradeContext is Trade with a caller-supplied context.
+func (c *Client) TradeContext(ctx context.Context, params TradeParams) (TradeResult, error) {
+	body, err := c.newPrvReq(ctx, prvMethodTrade, params.values())
+	if err != nil {
+		return TradeResult{}, err
+	}
+	return decodeEnvelope[TradeResult](prvMethodTrade, body)
+}
+
+// TradeHistoryParams are the parameters accepted by Client.TradeHistory.
+// From/To are optional trade-id bounds; Since/End are optional Unix timestamp bounds.
+type TradeHistoryParams struct {
+	Pair  Pair
+	Count int
+	From  int
+	To    int
+	Order string
+	Since int64
+	End   int64
+}
+
+func (p TradeHistoryParams) values() url.Values {
+	q := url.Values{}
+	q.Set("pair", string(p.Pair))
+	if p.Count != 0 {
+		q.Set("count", strconv.Itoa(p.Count))
+	}
+	if p.From != 0 {
+		q.Set("from", strconv.Itoa(p.From))
+	}
+	if p.To != 0 {
+		q.Set("to", strconv.Itoa(p.To))
+	}
+	if p.Order != "" {
+		q.Set("order", p.Order)
+	}
+	if p.Since != 0 {
+		q.Set("since", strconv.FormatInt(p.Since, 10))
+	}
+	if p.End != 0 {
+		q.Set("end", strconv.FormatInt(p.End, 10))
+	}
+	return q
+}
+
+// TradeHistoryEntry describes a single executed trade belonging to the account.
+type TradeHistoryEntry struct {
+	TradeID string `json:"trade_id"`
+	Date    string `json:"date"`
+	Price   string `json:"price"`
+	Type    string `json:"type"`
+	OrderID string `json:"order_id"`
+	Pair    Pair   `json:"pair"`
+	Fee     string `json:"fee"`
+}
+
+// TradeHistory fetches the account's executed trades for a pair.
+func (c *Client) TradeHistory(params TradeHistoryParams) ([]TradeHistoryEntry, error) {
+	return c.TradeHistoryContext(context.Background(), params)
+}
+
+// TradeHistoryContext is TradeHistory with a caller-supplied context.
+func (c *Client) TradeHistoryContext(ctx context.Context, params TradeHistoryParams) ([]TradeHistoryEntry, error) {
+	body, err := c.newPrvReq(ctx, prvMethodTradeHist, params.values())
+	if err != nil {
+		return nil, err
+	}
+	return decodeEnvelope[[]TradeHistoryEntry](prvMethodTradeHist, body)
+}
+
+// Order describes a resting or historical order on the book.
+type Order struct {
+	OrderID      int    `json:"order_id"`
+	SubmitTime   string `json:"submit_time"`
+	Price        string `json:"price"`
+	Type         string `json:"type"`
+	OrderRupiah  string `json:"order_rupiah,omitempty"`
+	RemainRupiah string `json:"remain_rupiah,omitempty"`
+	Status       string `json:"status,omitempty"`
+}
+
+// OpenOrdersParams are the parameters accepted by Client.OpenOrders.
+// Pair may be left empty to fetch open orders across every pair.
+type OpenOrdersParams struct {
+	Pair Pair
+}
+
+func (p OpenOrdersParams) values() url.Values {
+	q := url.Values{}
+	if p.Pair != "" {
+		q.Set("pair", string(p.Pair))
+	}
+	return q
+}
+
+type ordersEnvelope struct {
+	Orders []Order `json:"orders"`
+}
+
+// OpenOrders fetches the account's resting orders.
+func (c *Client) OpenOrders(params OpenOrdersParams) ([]Order, error) {
+	return c.OpenOrdersContext(context.Background(), params)
+}
+
+// OpenOrdersContext is OpenOrders with a caller-supplied context.
+func (c *Client) OpenOrdersContext(ctx context.Context, params OpenOrdersParams) ([]Order, error) {
+	body, err := c.newPrvReq(ctx, prvMethodOpenOrders, params.values())
+	if err != nil {
+		return nil, err
+	}
+	orders, err := decodeEnvelope[ordersEnvelope](prvMethodOpenOrders, body)
+	if err != nil {
+		return nil, err
+	}
+	return orders.Orders, nil
+}
+
+// OrderHistoryParams are the parameters accepted by Client.OrderHistory.
+type OrderHistoryParams struct {
+	Pair  Pair
+	Count int
+	From  int
+}
+
+func (p OrderHistoryParams) values() url.Values {
+	q := url.Values{}
+	q.Set("pair", string(p.Pair))
+	if p.Count != 0 {
+		q.Set("count", strconv.Itoa(p.Count))
+	}
+	if p.From != 0 {
+		q.Set("from", strconv.Itoa(p.From))
+	}
+	return q
+}
+
+// OrderHistory fetches the account's closed orders for a pair.
+func (c *Client) OrderHistory(params OrderHistoryParams) ([]Order, error) {
+	return c.OrderHistoryContext(context.Background(), params)
+}
+
+// OrderHistoryContext is OrderHistory with a caller-supplied context.
+func (c *Client) OrderHistoryContext(ctx context.Context, params OrderHistoryParams) ([]Order, error) {
+	body, err := c.newPrvReq(ctx, prvMethodOrderHist, params.values())
+	if err != nil {
+		return nil, err
+	}
+	orders, err := decodeEnvelope[ordersEnvelope](prvMethodOrderHist, body)
+	if err != nil {
+		return nil, err
+	}
+	return orders.Orders, nil
+}
+
+// GetOrderParams are the parameters accepted by Client.GetOrder.
+type GetOrderParams struct {
+	Pair    Pair
+	OrderID int
+}
+
+func (p GetOrderParams) values() url.Values {
+	q := url.Values{}
+	q.Set("pair", string(p.Pair))
+	q.Set("order_id", strconv.Itoa(p.OrderID))
+	return q
+}
+
+type orderEnvelope struct {
+	Order Order `json:"order"`
+}
+
+// GetOrder fetches the details of a single order by id.
+func (c *Client) GetOrder(params GetOrderParams) (Order, error) {
+	return c.GetOrderContext(context.Background(), params)
+}
+
+// GetOrderContext is GetOrder with a caller-supplied context.
+func (c *Client) GetOrderContext(ctx context.Context, params GetOrderParams) (Order, error) {
+	body, err := c.newPrvReq(ctx, prvMethodGetOrder, params.values())
+	if err != nil {
+		return Order{}, err
+	}
+	order, err := decodeEnvelope[orderEnvelope](prvMethodGetOrder, body)
+	if err != nil {
+		return Order{}, err
+	}
+	return order.Order, nil
+}
+
+// CancelOrderParams are the parameters accepted by Client.CancelOrder.
+type CancelOrderParams struct {
+	Pair    Pair
+	OrderID int
+	Type    OrderType
+}
+
+func (p CancelOrderParams) values() url.Values {
+	q := url.Values{}
+	q.Set("pair", string(p.Pair))
+	q.Set("order_id", strconv.Itoa(p.OrderID))
+	q.Set("type", string(p.Type))
+	return q
+}
+
+// CancelOrderResult is returned by a successful Client.CancelOrder call.
+type CancelOrderResult struct {
+	OrderID int    `json:"order_id"`
+	Type    string `json:"type"`
+}
+
+// CancelOrder cancels a resting order.
+func (c *Client) CancelOrder(params CancelOrderParams) (CancelOrderResult, error) {
+	return c.CancelOrderContext(context.Background(), params)
+}
+
+// CancelOrderContext is CancelOrder with a caller-supplied context.
+func (c *Client) CancelOrderContext(ctx context.Context, params CancelOrderParams) (CancelOrderResult, error) {
+	body, err := c.newPrvReq(ctx, prvMethodCancelOrder, params.values())
+	if err != nil {
+		return CancelOrderResult{}, err
+	}
+	return decodeEnvelope[CancelOrderResult](prvMethodCancelOrder, body)
+}
+
+// Transaction describes a single deposit or withdrawal entry.
+type Transaction struct {
+	Currency   string `json:"currency"`
+	Amount     string `json:"amount"`
+	SubmitTime string `json:"submit_time"`
+	Success    string `json:"success"`
+}
+
+// TransHistoryParams are the parameters accepted by Client.TransHistory.
+type TransHistoryParams struct {
+	From time.Time
+	To   time.Time
+}
+
+func (p TransHistoryParams) values() url.Values {
+	q := url.Values{}
+	q.Set("start", p.From.Format("2006-01-02"))
+	q.Set("end", p.To.Format("2006-01-02"))
+	return q
+}
+
+// TransHistoryResult is returned by a successful Client.TransHistory call.
+type TransHistoryResult struct {
+	Deposit  []Transaction `json:"deposit"`
+	Withdraw []Transaction `json:"withdraw"`
+}
+
+// TransHistory fetches the account's deposit and withdrawal history.
+func (c *Client) TransHistory(params TransHistoryParams) (TransHistoryResult, error) {
+	return c.TransHistoryContext(context.Background(), params)
+}
+
+// TransHistoryContext is TransHistory with a caller-supplied context.
+func (c *Client) TransHistoryContext(ctx context.Context, params TransHistoryParams) (TransHistoryResult, error) {
+	body, err := c.newPrvReq(ctx, prvMethodTransHist, params.values())
+	if err != nil {
+		return TransHistoryResult{}, err
+	}
+	return decodeEnvelope[TransHistoryResult](prvMethodTransHist, body)
+}
+
+// WithdrawCoinParams are the parameters accepted by Client.WithdrawCoin.
+// RequestID lets callers make withdrawal requests idempotent on retry.
+type WithdrawCoinParams struct {
+	Currency        string
+	WithdrawAmount  string
+	WithdrawAddress string
+	WithdrawMemo    string
+	RequestID       string
+}
+
+func (p WithdrawCoinParams) values() url.Values {
+	q := url.Values{}
+	q.Set("currency", p.Currency)
+	q.Set("withdraw_amount", p.WithdrawAmount)
+	q.Set("withdraw_address", p.WithdrawAddress)
+	if p.WithdrawMemo != "" {
+		q.Set("withdraw_memo", p.WithdrawMemo)
+	}
+	q.Set("requestId", p.RequestID)
+	return q
+}
+
+// WithdrawCoinResult is returned by a successful Client.WithdrawCoin call.
+type WithdrawCoinResult struct {
+	WithdrawID      string `json:"withdraw_id"`
+	Status          string `json:"status"`
+	WithdrawAmount  string `json:"withdraw_amount"`
+	Fee             string `json:"fee"`
+	SubmitTime      string `json:"submit_time"`
+	WithdrawAddress string `json:"withdraw_address"`
+}
+
+// WithdrawCoin submits a coin withdrawal request.
+func (c *Client) WithdrawCoin(params WithdrawCoinParams) (WithdrawCoinResult, error) {
+	return c.WithdrawCoinContext(context.Background(), params)
+}
+
+// WithdrawCoinContext is WithdrawCoin with a caller-supplied context.
+func (c *Client) WithdrawCoinContext(ctx context.Context, params WithdrawCoinParams) (WithdrawCoinResult, error) {
+	body, err := c.newPrvReq(ctx, prvMethodWithdCoin, params.values())
+	if err != nil {
+		return WithdrawCoinResult{}, err
+	}
+	return decodeEnvelope[WithdrawCoinResult](prvMethodWithdCoin, body)
+}
@@ -0,0 +1,189 @@
+package btcid
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPrvTestServer spins up a TLS test server that always answers with body,
+// returning a Client wired to talk to it in place of the real BTCID API.
+func newPrvTestServer(body string) (*httptest.Server, *Client) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, body)
+	}))
+	client := New(stubAPIKey, stubSecret, ts.Client())
+	client.Domain = ts.URL
+	return ts, client
+}
+
+// prvMethodCase is one row of a table-driven private-method test: either a
+// response body to serve, or closeConn to simulate a network failure.
+type prvMethodCase struct {
+	body      string
+	closeConn bool
+	wantErr   bool
+}
+
+func prvMethodCases(successBody string) map[string]prvMethodCase {
+	return map[string]prvMethodCase{
+		"success envelope": {
+			body: successBody,
+		},
+		"API error envelope": {
+			body:    `{"success":0,"error":"invalid nonce"}`,
+			wantErr: true,
+		},
+		"malformed JSON": {
+			body:    `not json`,
+			wantErr: true,
+		},
+		"network error": {
+			closeConn: true,
+			wantErr:   true,
+		},
+	}
+}
+
+func runPrvMethodCase(t *testing.T, tc prvMethodCase, call func(*Client) error) {
+	t.Helper()
+	ts, client := newPrvTestServer(tc.body)
+	if tc.closeConn {
+		ts.Close()
+	} else {
+		defer ts.Close()
+	}
+	err := call(client)
+	if tc.wantErr {
+		assert.Error(t, err)
+	} else {
+		assert.NoError(t, err)
+	}
+}
+
+// stubSigner is a fake Signer used to prove that private calls can be
+// mocked without a real API secret.
+type stubSigner struct {
+	calls int
+}
+
+func (s *stubSigner) Sign(payload []byte) (string, map[string]string, error) {
+	s.calls++
+	return "stub-signature", map[string]string{"Key": "stub-key", "Sign": "stub-signature"}, nil
+}
+
+func TestClientUsesCustomSigner(t *testing.T) {
+	ts, client := newPrvTestServer(`{"success":1,"return":{"user_id":"123"}}`)
+	defer ts.Close()
+
+	signer := &stubSigner{}
+	client.Signer = signer
+
+	_, err := client.GetInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signer.calls)
+}
+
+func TestGetInfoPrivate(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"user_id":"123"}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.GetInfo()
+				return err
+			})
+		})
+	}
+}
+
+func TestTrade(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"receive_amount":"1","spend_amount":"10000","order_id":1,"trade_id":"1"}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.Trade(TradeParams{Pair: PairBTCIDR, Type: OrderTypeBuy, Price: "10000", Amount: "1"})
+				return err
+			})
+		})
+	}
+}
+
+func TestTradeHistory(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":[{"trade_id":"1","date":"1","price":"1","type":"buy","order_id":"1","pair":"btc_idr","fee":"0"}]}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.TradeHistory(TradeHistoryParams{Pair: PairBTCIDR})
+				return err
+			})
+		})
+	}
+}
+
+func TestOpenOrders(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"orders":[]}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.OpenOrders(OpenOrdersParams{Pair: PairBTCIDR})
+				return err
+			})
+		})
+	}
+}
+
+func TestOrderHistory(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"orders":[]}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.OrderHistory(OrderHistoryParams{Pair: PairBTCIDR})
+				return err
+			})
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"order":{"order_id":1,"submit_time":"1","price":"1","type":"buy"}}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.GetOrder(GetOrderParams{Pair: PairBTCIDR, OrderID: 1})
+				return err
+			})
+		})
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"order_id":1,"type":"buy"}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.CancelOrder(CancelOrderParams{Pair: PairBTCIDR, OrderID: 1, Type: OrderTypeBuy})
+				return err
+			})
+		})
+	}
+}
+
+func TestTransHistory(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"deposit":[],"withdraw":[]}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.TransHistory(TransHistoryParams{From: time.Now().Add(-24 * time.Hour), To: time.Now()})
+				return err
+			})
+		})
+	}
+}
+
+func TestWithdrawCoin(t *testing.T) {
+	for name, tc := range prvMethodCases(`{"success":1,"return":{"withdraw_id":"1","status":"process"}}`) {
+		t.Run(name, func(t *testing.T) {
+			runPrvMethodCase(t, tc, func(c *Client) error {
+				_, err := c.WithdrawCoin(WithdrawCoinParams{Currency: "btc", WithdrawAmount: "1", WithdrawAddress: "addr", RequestID: "1"})
+				return err
+			})
+		})
+	}
+}
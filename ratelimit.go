@@ -0,0 +1,62 @@
+package btcid
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Default quotas, chosen conservatively against BTCID's documented
+// per-endpoint limits. Override with WithPublicRate/WithPrivateRate.
+const (
+	defaultPublicRate   = rate.Limit(10)
+	defaultPublicBurst  = 10
+	defaultPrivateRate  = rate.Limit(1)
+	defaultPrivateBurst = 1
+)
+
+// ErrRateLimited is returned when a request can't proceed without exceeding
+// the client's configured quota and waiting for a token was aborted, e.g. by
+// a canceled context, and when the server answers 429 Too Many Requests.
+var ErrRateLimited = errors.New("btcid: rate limited")
+
+// Option configures a Client at construction time.
+type Option func(*Client)
+
+// WithPublicRate overrides the token bucket guarding public REST calls.
+func WithPublicRate(r rate.Limit, burst int) Option {
+	return func(c *Client) {
+		c.publicLimiter = rate.NewLimiter(r, burst)
+	}
+}
+
+// WithPrivateRate overrides the token bucket guarding private REST calls.
+func WithPrivateRate(r rate.Limit, burst int) Option {
+	return func(c *Client) {
+		c.privateLimiter = rate.NewLimiter(r, burst)
+	}
+}
+
+// applyRetryAfter, when resp carries a Retry-After header, stalls limiter
+// until that window has elapsed by dropping its rate to zero and restoring
+// it afterward. This lets the server's own backpressure signal win over our
+// local quota guess.
+func applyRetryAfter(limiter *rate.Limiter, resp *http.Response) {
+	raw := resp.Header.Get("Retry-After")
+	if raw == "" {
+		return
+	}
+	secs, err := strconv.Atoi(raw)
+	if err != nil || secs <= 0 {
+		return
+	}
+
+	original := limiter.Limit()
+	limiter.SetLimit(0)
+	time.AfterFunc(time.Duration(secs)*time.Second, func() {
+		limiter.SetLimit(original)
+	})
+}
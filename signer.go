@@ -0,0 +1,41 @@
+package btcid
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+)
+
+// Signer authenticates a private request payload, returning the signature
+// and any headers that must be attached to the outgoing request. Extracting
+// this from the transport lets callers swap in alternative signing schemes
+// (e.g. an HSM-backed signer) and lets tests mock private calls without a
+// real API secret.
+type Signer interface {
+	Sign(payload []byte) (signature string, headers map[string]string, err error)
+}
+
+// HMACSHA512Signer is the default Signer, matching BTCID's documented
+// HMAC-SHA512 request signing scheme.
+type HMACSHA512Signer struct {
+	APIKey string
+	Secret string
+}
+
+// NewHMACSHA512Signer returns a Signer that signs with HMAC-SHA512 keyed on
+// secret and attaches the Key/Sign headers BTCID's private API requires.
+func NewHMACSHA512Signer(apiKey, secret string) *HMACSHA512Signer {
+	return &HMACSHA512Signer{APIKey: apiKey, Secret: secret}
+}
+
+// Sign computes the HMAC-SHA512 signature of payload and returns the Key
+// and Sign headers BTCID expects on every private request.
+func (s *HMACSHA512Signer) Sign(payload []byte) (string, map[string]string, error) {
+	mac := hmac.New(sha512.New, []byte(s.Secret))
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+	return signature, map[string]string{
+		"Key":  s.APIKey,
+		"Sign": signature,
+	}, nil
+}
@@ -0,0 +1,308 @@
+package btcid
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultStreamURL     = "wss://ws3.indodax.com/ws/"
+	streamPingInterval   = 20 * time.Second
+	streamInitialBackoff = time.Second
+	streamMaxBackoff     = time.Minute
+)
+
+// TickerHandler is invoked for every ticker update received on a Stream.
+type TickerHandler func(pair string, ticker Ticker)
+
+// TradeHandler is invoked for every trade update received on a Stream.
+type TradeHandler func(pair string, trade Trade)
+
+// DepthHandler is invoked for every depth update received on a Stream.
+type DepthHandler func(pair string, depth Depth)
+
+// Stream manages a persistent websocket connection to BTCID's realtime feed,
+// reconnecting automatically and re-subscribing to every active channel.
+type Stream struct {
+	URL    string
+	Dialer *websocket.Dialer
+
+	mu            sync.Mutex
+	conn          *websocket.Conn
+	started       bool // true once the run() goroutine has been spawned
+	subscriptions map[string]map[string]struct{} // pair -> channel set
+
+	onTicker TickerHandler
+	onTrade  TradeHandler
+	onDepth  DepthHandler
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewStream returns a Stream ready to Subscribe against BTCID's default
+// realtime endpoint.
+func NewStream() *Stream {
+	return &Stream{
+		URL:           defaultStreamURL,
+		Dialer:        websocket.DefaultDialer,
+		subscriptions: make(map[string]map[string]struct{}),
+		closeCh:       make(chan struct{}),
+	}
+}
+
+// OnTickerUpdate registers the callback invoked for ticker updates.
+func (s *Stream) OnTickerUpdate(fn TickerHandler) {
+	s.mu.Lock()
+	s.onTicker = fn
+	s.mu.Unlock()
+}
+
+// OnTradeUpdate registers the callback invoked for trade updates.
+func (s *Stream) OnTradeUpdate(fn TradeHandler) {
+	s.mu.Lock()
+	s.onTrade = fn
+	s.mu.Unlock()
+}
+
+// OnDepthUpdate registers the callback invoked for depth updates.
+func (s *Stream) OnDepthUpdate(fn DepthHandler) {
+	s.mu.Lock()
+	s.onDepth = fn
+	s.mu.Unlock()
+}
+
+// Subscribe adds a pair/channel combination to the stream, connecting the
+// underlying websocket on the first call and sending the subscribe message
+// immediately on subsequent ones.
+func (s *Stream) Subscribe(pair string, channels ...string) error {
+	s.mu.Lock()
+	if s.subscriptions[pair] == nil {
+		s.subscriptions[pair] = make(map[string]struct{})
+	}
+	for _, ch := range channels {
+		s.subscriptions[pair][ch] = struct{}{}
+	}
+	conn := s.conn
+	alreadyStarted := s.started
+	s.started = true
+	s.mu.Unlock()
+
+	if !alreadyStarted {
+		go s.run()
+		return nil
+	}
+	if conn == nil {
+		// run() is still dialing; it will pick up this subscription once
+		// connected via resubscribeAll.
+		return nil
+	}
+	return s.sendSubscribe(conn, pair, channels)
+}
+
+// run owns the connection lifecycle: dial, subscribe, read until the
+// connection drops, then reconnect with exponential backoff until Close is
+// called.
+func (s *Stream) run() {
+	backoff := streamInitialBackoff
+	for {
+		select {
+		case <-s.closeCh:
+			return
+		default:
+		}
+
+		conn, _, err := s.Dialer.Dial(s.URL, nil)
+		if err != nil {
+			log.Printf("btcid: stream dial error: %v", err)
+			time.Sleep(backoff)
+			backoff = nextBackoff(backoff)
+			continue
+		}
+		backoff = streamInitialBackoff
+
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+
+		if err := s.resubscribeAll(conn); err != nil {
+			log.Printf("btcid: stream resubscribe error: %v", err)
+		}
+
+		s.readLoop(conn)
+		conn.Close()
+
+		s.mu.Lock()
+		s.conn = nil
+		s.mu.Unlock()
+
+		select {
+		case <-s.closeCh:
+			return
+		default:
+		}
+	}
+}
+
+func nextBackoff(cur time.Duration) time.Duration {
+	next := cur * 2
+	if next > streamMaxBackoff {
+		next = streamMaxBackoff
+	}
+	return next
+}
+
+func (s *Stream) resubscribeAll(conn *websocket.Conn) error {
+	s.mu.Lock()
+	subs := make(map[string][]string, len(s.subscriptions))
+	for pair, channels := range s.subscriptions {
+		for ch := range channels {
+			subs[pair] = append(subs[pair], ch)
+		}
+	}
+	s.mu.Unlock()
+
+	for pair, channels := range subs {
+		if err := s.sendSubscribe(conn, pair, channels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Stream) sendSubscribe(conn *websocket.Conn, pair string, channels []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return conn.WriteJSON(struct {
+		Event    string   `json:"event"`
+		Pair     string   `json:"pair"`
+		Channels []string `json:"channels"`
+	}{Event: "subscribe", Pair: pair, Channels: channels})
+}
+
+// readLoop reads frames off conn until it errors out, decompressing gzip
+// binary frames and keeping the connection alive with ping/pong keepalive.
+func (s *Stream) readLoop(conn *websocket.Conn) {
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * streamPingInterval))
+	})
+	_ = conn.SetReadDeadline(time.Now().Add(2 * streamPingInterval))
+
+	done := make(chan struct{})
+	defer close(done)
+	go s.pingLoop(conn, done)
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType == websocket.BinaryMessage {
+			if decompressed, err := GzipDecompress(data); err == nil {
+				data = decompressed
+			}
+		}
+		s.dispatch(data)
+	}
+}
+
+func (s *Stream) pingLoop(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-s.closeCh:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			err := conn.WriteMessage(websocket.PingMessage, nil)
+			s.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+type streamEvent struct {
+	Channel string          `json:"channel"`
+	Pair    string          `json:"pair"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (s *Stream) dispatch(data []byte) {
+	evt := streamEvent{}
+	if err := json.Unmarshal(data, &evt); err != nil {
+		log.Printf("btcid: stream decode error: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	onTicker, onTrade, onDepth := s.onTicker, s.onTrade, s.onDepth
+	s.mu.Unlock()
+
+	switch evt.Channel {
+	case "ticker":
+		if onTicker == nil {
+			return
+		}
+		ticker := Ticker{}
+		if err := json.Unmarshal(evt.Data, &ticker); err != nil {
+			return
+		}
+		onTicker(evt.Pair, ticker)
+	case "trade":
+		if onTrade == nil {
+			return
+		}
+		trade := Trade{}
+		if err := json.Unmarshal(evt.Data, &trade); err != nil {
+			return
+		}
+		onTrade(evt.Pair, trade)
+	case "depth":
+		if onDepth == nil {
+			return
+		}
+		depth := Depth{}
+		if err := json.Unmarshal(evt.Data, &depth); err != nil {
+			return
+		}
+		onDepth(evt.Pair, depth)
+	}
+}
+
+// Close shuts down the stream and its underlying connection, if any.
+func (s *Stream) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+		if conn != nil {
+			err = conn.Close()
+		}
+	})
+	return err
+}
+
+// GzipDecompress inflates a gzip-compressed websocket frame payload, as sent
+// by BTCID's realtime feed for ticker/trade/depth updates.
+func GzipDecompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}